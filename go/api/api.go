@@ -0,0 +1,12 @@
+// Package api embeds the OpenAPI specification describing the HTTP API, so
+// the spec ships inside the binary for /openapi.json and the Swagger UI route
+// instead of needing to be deployed alongside it.
+package api
+
+import "embed"
+
+//go:embed openapi.yaml
+var specFS embed.FS
+
+// Spec returns the raw OpenAPI 3 YAML document.
+func Spec() ([]byte, error) { return specFS.ReadFile("openapi.yaml") }
@@ -11,21 +11,44 @@ import (
 	"time"
 
 	app "example.com/todo/internal/app"
-	repomem "example.com/todo/internal/adapters/repo/memory"
+	hmacjwt "example.com/todo/internal/adapters/auth/hmacjwt"
 	httpadp "example.com/todo/internal/adapters/http"
+	nooplogger "example.com/todo/internal/adapters/logger/noop"
+	sloglogger "example.com/todo/internal/adapters/logger/slog"
+	zaplogger "example.com/todo/internal/adapters/logger/zap"
+	repomem "example.com/todo/internal/adapters/repo/memory"
+	reposql "example.com/todo/internal/adapters/repo/sql"
+	"example.com/todo/internal/events"
+	"example.com/todo/internal/ports"
 )
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
+	logger := newLogger()
+
 	// Adapters (driven): Infrastructure implementations
-	repo := repomem.New()
+	repo, closeRepo := newRepo()
+	defer closeRepo()
+
+	jwtSecret := os.Getenv("TODO_JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "dev-secret-change-me"
+		log.Println("warning: TODO_JWT_SECRET not set, using an insecure development default")
+	}
+	jwtIssuer := os.Getenv("TODO_JWT_ISSUER")
+	if jwtIssuer == "" {
+		jwtIssuer = "todo-api"
+	}
+	verifier := hmacjwt.NewVerifier([]byte(jwtSecret), jwtIssuer)
+	issuer := hmacjwt.NewIssuer([]byte(jwtSecret), jwtIssuer, 15*time.Minute, 30*24*time.Hour)
 
 	// Application core (use cases)
-	svc := app.NewTodoService(repo)
+	broker := events.NewBroker(logger)
+	svc := app.NewTodoService(repo, logger, broker)
 
 	// Adapters (driver): HTTP server exposing the app via ports
-	h := httpadp.NewServer(svc)
+	h := httpadp.NewServer(svc, verifier, issuer, logger, repo, broker)
 
 	srv := &http.Server{
 		Addr:         ":8081",
@@ -49,5 +72,45 @@ func main() {
 	log.Println("shutting down...")
 	shCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	srv.RegisterOnShutdown(broker.Close)
 	_ = srv.Shutdown(shCtx)
 }
+
+// newLogger picks the Logger adapter named by TODO_LOG_ADAPTER ("slog",
+// "zap", or "noop"), defaulting to the stdlib slog adapter.
+func newLogger() ports.Logger {
+	switch os.Getenv("TODO_LOG_ADAPTER") {
+	case "zap":
+		l, err := zaplogger.New()
+		if err != nil {
+			log.Fatalf("zap logger: %v", err)
+		}
+		return l
+	case "noop":
+		return nooplogger.New()
+	default:
+		return sloglogger.New()
+	}
+}
+
+// repository is satisfied by every TodoRepo adapter we ship; both also
+// implement ports.Pinger so /health can check them.
+type repository interface {
+	ports.TodoRepo
+	ports.Pinger
+}
+
+// newRepo picks the TodoRepo adapter: a SQL store (SQLite or Postgres,
+// depending on the DSN) when TODO_DB_DSN is set, otherwise the in-memory
+// adapter. The returned func closes any resources the adapter opened.
+func newRepo() (repository, func()) {
+	dsn := os.Getenv("TODO_DB_DSN")
+	if dsn == "" {
+		return repomem.New(), func() {}
+	}
+	store, err := reposql.Open(context.Background(), dsn)
+	if err != nil {
+		log.Fatalf("open TODO_DB_DSN: %v", err)
+	}
+	return store, func() { _ = store.Close() }
+}
@@ -0,0 +1,4 @@
+// Package gen holds the server interface and wire models described by
+// api/openapi.yaml. These are hand-written, not produced by a code
+// generator: keep them in sync with the spec by hand when either changes.
+package gen
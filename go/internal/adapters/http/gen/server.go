@@ -0,0 +1,48 @@
+package gen
+
+import "net/http"
+
+// ServerInterface is implemented by the driver adapter; RegisterHandlers
+// invokes one method per request/response operation defined in
+// api/openapi.yaml. The StreamTodos and StreamTodosWS operations have no
+// method here.
+type ServerInterface interface {
+	Health(w http.ResponseWriter, r *http.Request)
+	Login(w http.ResponseWriter, r *http.Request)
+	Refresh(w http.ResponseWriter, r *http.Request)
+	ListTodos(w http.ResponseWriter, r *http.Request)
+	CreateTodo(w http.ResponseWriter, r *http.Request)
+	ToggleTodo(w http.ResponseWriter, r *http.Request)
+	DeleteTodo(w http.ResponseWriter, r *http.Request)
+}
+
+// Router is the subset of router.Router that RegisterHandlers needs, kept
+// narrow so gen doesn't depend on the http adapter package.
+type Router interface {
+	Route(pattern string, methods map[string]http.Handler)
+}
+
+// RegisterHandlers mounts every ServerInterface operation onto r. mw
+// wraps each operation's handler by name, letting the caller decide which
+// operations need auth, validation, or neither.
+func RegisterHandlers(r Router, si ServerInterface, mw func(op string, h http.HandlerFunc) http.Handler) {
+	r.Route("/health", map[string]http.Handler{
+		http.MethodGet: mw("Health", si.Health),
+	})
+	r.Route("/auth/login", map[string]http.Handler{
+		http.MethodPost: mw("Login", si.Login),
+	})
+	r.Route("/auth/refresh", map[string]http.Handler{
+		http.MethodPost: mw("Refresh", si.Refresh),
+	})
+	r.Route("/todos", map[string]http.Handler{
+		http.MethodGet:  mw("ListTodos", si.ListTodos),
+		http.MethodPost: mw("CreateTodo", si.CreateTodo),
+	})
+	r.Route("/todos/{id}/toggle", map[string]http.Handler{
+		http.MethodPost: mw("ToggleTodo", si.ToggleTodo),
+	})
+	r.Route("/todos/{id}", map[string]http.Handler{
+		http.MethodDelete: mw("DeleteTodo", si.DeleteTodo),
+	})
+}
@@ -0,0 +1,49 @@
+package gen
+
+import "time"
+
+// Todo is the wire representation of api/openapi.yaml's Todo schema.
+type Todo struct {
+	Id        string    `json:"id"`
+	Title     string    `json:"title"`
+	Done      bool      `json:"done"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TodoPage is one page of ListTodos results.
+type TodoPage struct {
+	Items      []Todo  `json:"items"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// CreateTodoRequest is the CreateTodo request body.
+type CreateTodoRequest struct {
+	Title string `json:"title"`
+}
+
+// LoginRequest is the Login request body.
+type LoginRequest struct {
+	Username string `json:"username"`
+}
+
+// RefreshRequest is the Refresh request body.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenPair is the Login/Refresh response body.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Problem is an RFC 7807 application/problem+json error body.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Field    string `json:"field,omitempty"`
+}
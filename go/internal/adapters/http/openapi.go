@@ -0,0 +1,49 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"example.com/todo/api"
+
+	"gopkg.in/yaml.v3"
+)
+
+// handleOpenAPIJSON serves api/openapi.yaml as JSON, since Swagger UI (and
+// most tooling) expects the spec over HTTP in that form.
+func (s *Server) handleOpenAPIJSON(w http.ResponseWriter, r *http.Request) {
+	raw, err := api.Spec()
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, "Internal error", err.Error(), "")
+		return
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, "Internal error", err.Error(), "")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(doc)
+}
+
+// swaggerUIPage points Swagger UI, loaded from a CDN, at /openapi.json. It's
+// a plain static page; there's no templating or asset pipeline in this repo.
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>todo API docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => SwaggerUIBundle({url: '/openapi.json', dom_id: '#swagger-ui'})
+  </script>
+</body>
+</html>`
+
+func (s *Server) handleSwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(swaggerUIPage))
+}
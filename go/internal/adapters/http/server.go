@@ -2,100 +2,209 @@
 package http
 
 import (
+	"bufio"
+	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	app "example.com/todo/internal/app"
+	"example.com/todo/internal/adapters/http/gen"
+	"example.com/todo/internal/domain"
+	"example.com/todo/internal/events"
+	"example.com/todo/internal/ports"
+	"example.com/todo/internal/reqctx"
+	"example.com/todo/internal/router"
 )
 
+type ctxKey int
+
+const userIDKey ctxKey = iota
+
 type Server struct {
-	svc *app.TodoService
-	mux *http.ServeMux
+	svc      *app.TodoService
+	mux      *router.Router
+	verifier ports.AuthVerifier
+	issuer   ports.TokenIssuer
+	logger   ports.Logger
+	pinger   ports.Pinger
+	broker   *events.Broker
 }
 
-func NewServer(svc *app.TodoService) *Server {
-	s := &Server{svc: svc, mux: http.NewServeMux()}
+func NewServer(svc *app.TodoService, verifier ports.AuthVerifier, issuer ports.TokenIssuer, logger ports.Logger, pinger ports.Pinger, broker *events.Broker) *Server {
+	s := &Server{svc: svc, mux: router.New(), verifier: verifier, issuer: issuer, logger: logger, pinger: pinger, broker: broker}
 	s.routes()
 	return s
 }
 
-func (s *Server) Router() http.Handler { return s.corsMiddleware(s.logMiddleware(s.mux)) }
+func (s *Server) Router() http.Handler {
+	return s.corsMiddleware(s.requestIDMiddleware(s.logMiddleware(s.mux)))
+}
 
+// routes mounts the hand-written operations from package gen (kept in sync
+// with api/openapi.yaml by hand), the live event streams (outside the
+// validated surface), and the spec and docs routes that aren't part of the
+// API surface itself.
 func (s *Server) routes() {
-	s.mux.HandleFunc("/health", s.handleHealth)
-	s.mux.HandleFunc("/todos", s.handleTodos)
-	s.mux.HandleFunc("/todos/", s.handleTodoByID)
+	// These two literal paths would otherwise be shadowed by the
+	// parameterized "/todos/{id}" route gen.RegisterHandlers registers
+	// below, since the router dispatches to the first matching pattern.
+	s.mux.Route("/todos/stream", map[string]http.Handler{
+		http.MethodGet: s.requireAuth(s.handleEventStream),
+	})
+	s.mux.Route("/todos/ws", map[string]http.Handler{
+		http.MethodGet: s.requireAuth(s.handleEventWS),
+	})
+	gen.RegisterHandlers(s.mux, s, s.middlewareFor)
+	s.mux.Route("/openapi.json", map[string]http.Handler{
+		http.MethodGet: http.HandlerFunc(s.handleOpenAPIJSON),
+	})
+	s.mux.Route("/docs", map[string]http.Handler{
+		http.MethodGet: http.HandlerFunc(s.handleSwaggerUI),
+	})
+}
+
+// middlewareFor wraps op's handler with a required-field presence check,
+// and with bearer-auth for every operation under /todos.
+func (s *Server) middlewareFor(op string, h http.HandlerFunc) http.Handler {
+	h = requireFields(op, h)
+	switch op {
+	case "ListTodos", "CreateTodo", "ToggleTodo", "DeleteTodo":
+		return s.requireAuth(h)
+	default:
+		return h
+	}
 }
 
-// ---------- Handlers ----------
-func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+// ---------- Handlers (gen.ServerInterface) ----------
+func (s *Server) Health(w http.ResponseWriter, r *http.Request) {
+	if err := s.pinger.Ping(r.Context()); err != nil {
+		writeProblem(w, r, http.StatusServiceUnavailable, "Service unavailable", err.Error(), "")
+		return
+	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
-func (s *Server) handleTodos(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		list, err := s.svc.List(r.Context())
-		if err != nil {
-			writeErr(w, http.StatusInternalServerError, err)
-			return
-		}
-		writeJSON(w, http.StatusOK, list)
-	case http.MethodPost:
-		var in struct{ Title string `json:"title"` }
-		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-			writeErr(w, http.StatusBadRequest, errors.New("invalid JSON body"))
-			return
-		}
-		t, err := s.svc.Create(r.Context(), in.Title)
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, err)
-			return
-		}
-		writeJSON(w, http.StatusCreated, t)
-	default:
-		w.Header().Set("Allow", "GET, POST")
-		writeErr(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+func (s *Server) Login(w http.ResponseWriter, r *http.Request) {
+	var in gen.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid body", "request body is not valid JSON", "")
+		return
 	}
+	// There's no user store yet, so the subject is whatever the caller
+	// presents. Swap in a real identity check (password, OIDC, ...) behind
+	// this handler once one exists; the issuer/verifier ports don't change.
+	username := strings.TrimSpace(in.Username)
+	if username == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Missing required field", "username is required", "username")
+		return
+	}
+	access, refresh, err := s.issuer.Issue(r.Context(), username)
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, "Internal error", err.Error(), "")
+		return
+	}
+	writeJSON(w, http.StatusOK, gen.TokenPair{AccessToken: access, RefreshToken: refresh})
 }
 
-func (s *Server) handleTodoByID(w http.ResponseWriter, r *http.Request) {
-	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/todos/"), "/")
-	if len(parts) == 0 || parts[0] == "" {
-		writeErr(w, http.StatusBadRequest, errors.New("missing id"))
+func (s *Server) Refresh(w http.ResponseWriter, r *http.Request) {
+	var in gen.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid body", "request body is not valid JSON", "")
+		return
+	}
+	access, refresh, err := s.issuer.Refresh(r.Context(), in.RefreshToken)
+	if err != nil {
+		unauthorized(w, r)
 		return
 	}
-	id := parts[0]
+	writeJSON(w, http.StatusOK, gen.TokenPair{AccessToken: access, RefreshToken: refresh})
+}
 
-	if len(parts) == 2 && parts[1] == "toggle" {
-		if r.Method != http.MethodPost {
-			w.Header().Set("Allow", "POST")
-			writeErr(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
-			return
-		}
-		updated, err := s.svc.Toggle(r.Context(), id)
+func (s *Server) ListTodos(w http.ResponseWriter, r *http.Request) {
+	userID, _ := userIDFromContext(r.Context())
+	q := r.URL.Query()
+	if !q.Has("limit") && !q.Has("cursor") {
+		list, err := s.svc.List(r.Context(), userID)
 		if err != nil {
-			writeErr(w, http.StatusNotFound, err)
+			writeProblem(w, r, http.StatusInternalServerError, "Internal error", err.Error(), "")
 			return
 		}
-		writeJSON(w, http.StatusOK, updated)
+		writeJSON(w, http.StatusOK, toGenTodos(list))
 		return
 	}
 
-	switch r.Method {
-	case http.MethodDelete:
-		if err := s.svc.Delete(r.Context(), id); err != nil {
-			writeErr(w, http.StatusNotFound, err)
+	limit := 0
+	if raw := q.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			writeProblem(w, r, http.StatusBadRequest, "Invalid query parameter", "limit must be a non-negative integer", "limit")
 			return
 		}
-		w.WriteHeader(http.StatusNoContent)
-	default:
-		w.Header().Set("Allow", "DELETE, POST")
-		writeErr(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		limit = n
+	}
+	items, next, err := s.svc.ListPage(r.Context(), userID, limit, q.Get("cursor"))
+	if err != nil {
+		writeProblem(w, r, http.StatusInternalServerError, "Internal error", err.Error(), "")
+		return
+	}
+	page := gen.TodoPage{Items: toGenTodos(items)}
+	if next != "" {
+		page.NextCursor = &next
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) CreateTodo(w http.ResponseWriter, r *http.Request) {
+	userID, _ := userIDFromContext(r.Context())
+	var in gen.CreateTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid body", "request body is not valid JSON", "")
+		return
+	}
+	t, err := s.svc.Create(r.Context(), userID, in.Title)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid request", err.Error(), "title")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toGenTodo(t))
+}
+
+func (s *Server) ToggleTodo(w http.ResponseWriter, r *http.Request) {
+	userID, _ := userIDFromContext(r.Context())
+	updated, err := s.svc.Toggle(r.Context(), userID, router.Param(r, "id"))
+	if err != nil {
+		writeProblem(w, r, http.StatusNotFound, "Not found", err.Error(), "id")
+		return
 	}
+	writeJSON(w, http.StatusOK, toGenTodo(updated))
+}
+
+func (s *Server) DeleteTodo(w http.ResponseWriter, r *http.Request) {
+	userID, _ := userIDFromContext(r.Context())
+	if err := s.svc.Delete(r.Context(), userID, router.Param(r, "id")); err != nil {
+		writeProblem(w, r, http.StatusNotFound, "Not found", err.Error(), "id")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toGenTodo(t domain.Todo) gen.Todo {
+	return gen.Todo{Id: t.ID, Title: t.Title, Done: t.Done, CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt}
+}
+
+func toGenTodos(in []domain.Todo) []gen.Todo {
+	out := make([]gen.Todo, len(in))
+	for i, t := range in {
+		out[i] = toGenTodo(t)
+	}
+	return out
 }
 
 // ---------- Middleware & Helpers ----------
@@ -104,32 +213,111 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-Start, X-CSRF-Token")
-		
+
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
 			return
 		}
-		
+
 		next.ServeHTTP(w, r)
 	})
 }
 
+// requireAuth guards /todos* with a bearer-token check, stores the verified
+// subject on the request context, and responds 401 with a WWW-Authenticate
+// header when the token is missing or invalid. /health and /auth/* stay public.
+func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			unauthorized(w, r)
+			return
+		}
+		sub, err := s.verifier.Verify(r.Context(), token)
+		if err != nil {
+			unauthorized(w, r)
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), userIDKey, sub)))
+	}
+}
+
+// bearerToken reads the token from the Authorization header, falling back
+// to an access_token query parameter. The fallback exists because browser
+// EventSource and WebSocket clients can't set custom request headers, so
+// /todos/stream and /todos/ws have no other way to authenticate.
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, prefix) {
+		return strings.TrimPrefix(h, prefix), true
+	}
+	if tok := r.URL.Query().Get("access_token"); tok != "" {
+		return tok, true
+	}
+	return "", false
+}
+
+func userIDFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(userIDKey).(string)
+	return v, ok
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	writeProblem(w, r, http.StatusUnauthorized, "Unauthorized", "missing or invalid bearer token", "")
+}
+
+// RequestIDHeader is the header requestIDMiddleware reads or sets a
+// correlation id on, and that's echoed back to the caller.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDMiddleware reads X-Request-ID from the incoming request or
+// generates a new one, stores it on the context via reqctx, and echoes it
+// back on the response so callers and logs can correlate a request end-to-end.
+func (s *Server) requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(reqctx.WithRequestID(r.Context(), id)))
+	})
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// logMiddleware logs one structured line per request through the Logger
+// port: method, path, status, duration, remote addr, bytes written, and the
+// request ID set by requestIDMiddleware, so a single request can be traced
+// across the HTTP layer and the business events TodoService logs.
 func (s *Server) logMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		lrw := &loggingRW{ResponseWriter: w, status: 200}
+		lrw := &loggingRW{ResponseWriter: w, status: http.StatusOK}
 		next.ServeHTTP(lrw, r)
-		dur := time.Since(start)
-		// basic structured log
-		// In real world, inject a Logger port and implement adapters (zap/logrus/std)
-		// Here we keep it minimal with stdlib.
-		println(r.Method, r.URL.Path, lrw.status, dur.String())
+		s.logger.Info("http request",
+			"request_id", reqctx.RequestID(r.Context()),
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", lrw.status,
+			"duration", time.Since(start).String(),
+			"remote_addr", r.RemoteAddr,
+			"bytes", lrw.bytes,
+		)
 	})
 }
 
 type loggingRW struct {
 	http.ResponseWriter
 	status int
+	bytes  int64
 }
 
 func (lrw *loggingRW) WriteHeader(code int) {
@@ -137,12 +325,38 @@ func (lrw *loggingRW) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+func (lrw *loggingRW) Write(b []byte) (int, error) {
+	n, err := lrw.ResponseWriter.Write(b)
+	lrw.bytes += int64(n)
+	return n, err
+}
+
+// Flush forwards to the underlying ResponseWriter's Flusher, so SSE
+// handlers can stream through logMiddleware. Without this, wrapping every
+// route (including /todos/stream) in logMiddleware would silently turn off
+// streaming: a type assertion for http.Flusher on *loggingRW would fail.
+func (lrw *loggingRW) Flush() {
+	if f, ok := lrw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack forwards to the underlying ResponseWriter's Hijacker, so the
+// WebSocket upgrade in handleEventWS still works through logMiddleware.
+func (lrw *loggingRW) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := lrw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("underlying ResponseWriter does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+// Unwrap lets callers (e.g. net/http's own response-controller helpers)
+// reach the real ResponseWriter underneath the logging wrapper.
+func (lrw *loggingRW) Unwrap() http.ResponseWriter { return lrw.ResponseWriter }
+
 func writeJSON(w http.ResponseWriter, code int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 	_ = json.NewEncoder(w).Encode(v)
 }
-
-func writeErr(w http.ResponseWriter, code int, err error) {
-	writeJSON(w, code, map[string]string{"error": err.Error()})
-}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+
+	"example.com/todo/internal/events"
+)
+
+// heartbeatInterval keeps idle SSE connections (and any intermediary
+// proxies) from timing out the connection.
+const heartbeatInterval = 15 * time.Second
+
+// handleEventStream serves GET /todos/stream as Server-Sent Events: one
+// "todo.*" event per change to the caller's todos, with a heartbeat comment
+// every 15s. A Last-Event-ID header (from EventSource reconnects) replays
+// anything missed from the broker's ring buffer.
+func (s *Server) handleEventStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeProblem(w, r, http.StatusInternalServerError, "Streaming unsupported", "the response writer does not support flushing", "")
+		return
+	}
+	userID, _ := userIDFromContext(r.Context())
+	rawLastEventID := r.Header.Get("Last-Event-ID")
+	lastEventID, _ := strconv.ParseUint(rawLastEventID, 10, 64)
+
+	ch, replay, unsubscribe := s.broker.Subscribe(userID, lastEventID)
+	defer unsubscribe()
+	if rawLastEventID == "" {
+		// A first connection has missed nothing; only reconnects replay.
+		replay = nil
+	}
+
+	// Lift the server's WriteTimeout for this response, which would
+	// otherwise end the stream before the first heartbeat is sent.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, evt := range replay {
+		if err := writeSSEEvent(w, evt); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case evt, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := writeSSEEvent(w, evt); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeSSEEvent(w http.ResponseWriter, evt events.Event) error {
+	data, err := json.Marshal(toGenTodo(evt.Todo))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", evt.ID, evt.Type, data)
+	return err
+}
+
+// handleEventWS serves GET /todos/ws: the same event stream as
+// /todos/stream, over a WebSocket connection instead of SSE, for clients
+// that prefer a persistent bidirectional socket.
+func (s *Server) handleEventWS(w http.ResponseWriter, r *http.Request) {
+	userID, _ := userIDFromContext(r.Context())
+
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "bye")
+
+	ch, _, unsubscribe := s.broker.Subscribe(userID, 0)
+	defer unsubscribe()
+
+	ctx := conn.CloseRead(r.Context())
+	for {
+		select {
+		case evt, ok := <-ch:
+			if !ok {
+				return
+			}
+			msg := struct {
+				ID   uint64 `json:"id"`
+				Type string `json:"type"`
+				Todo any    `json:"todo"`
+			}{ID: evt.ID, Type: string(evt.Type), Todo: toGenTodo(evt.Todo)}
+			if err := wsjson.Write(ctx, conn, msg); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// Package noop is a ports.Logger implementation that discards everything.
+// Useful for tests and as a safe zero-config default.
+package noop
+
+type Logger struct{}
+
+func New() Logger { return Logger{} }
+
+func (Logger) Debug(string, ...any) {}
+func (Logger) Info(string, ...any)  {}
+func (Logger) Warn(string, ...any)  {}
+func (Logger) Error(string, ...any) {}
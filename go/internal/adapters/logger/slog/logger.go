@@ -0,0 +1,22 @@
+// Package slog adapts the stdlib log/slog package to ports.Logger. It's the
+// default adapter: no third-party dependency, JSON lines on stdout.
+package slog
+
+import (
+	"log/slog"
+	"os"
+)
+
+type Logger struct {
+	l *slog.Logger
+}
+
+// New returns a Logger that writes JSON lines to stdout.
+func New() *Logger {
+	return &Logger{l: slog.New(slog.NewJSONHandler(os.Stdout, nil))}
+}
+
+func (lg *Logger) Debug(msg string, kv ...any) { lg.l.Debug(msg, kv...) }
+func (lg *Logger) Info(msg string, kv ...any)  { lg.l.Info(msg, kv...) }
+func (lg *Logger) Warn(msg string, kv ...any)  { lg.l.Warn(msg, kv...) }
+func (lg *Logger) Error(msg string, kv ...any) { lg.l.Error(msg, kv...) }
@@ -0,0 +1,22 @@
+// Package zap adapts Uber's zap logger to ports.Logger.
+package zap
+
+import "go.uber.org/zap"
+
+type Logger struct {
+	l *zap.SugaredLogger
+}
+
+// New returns a production-configured zap Logger.
+func New() (*Logger, error) {
+	zl, err := zap.NewProduction()
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{l: zl.Sugar()}, nil
+}
+
+func (lg *Logger) Debug(msg string, kv ...any) { lg.l.Debugw(msg, kv...) }
+func (lg *Logger) Info(msg string, kv ...any)  { lg.l.Infow(msg, kv...) }
+func (lg *Logger) Warn(msg string, kv ...any)  { lg.l.Warnw(msg, kv...) }
+func (lg *Logger) Error(msg string, kv ...any) { lg.l.Errorw(msg, kv...) }
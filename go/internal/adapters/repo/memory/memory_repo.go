@@ -18,7 +18,7 @@ type memRepo struct {
 
 func New() *memRepo { return &memRepo{data: make(map[string]domain.Todo)} }
 
-	func (m *memRepo) Create(ctx context.Context, title string) (domain.Todo, error) {
+func (m *memRepo) Create(ctx context.Context, ownerID, title string) (domain.Todo, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	// Generate a shorter ID that's safe for JavaScript
@@ -26,16 +26,19 @@ func New() *memRepo { return &memRepo{data: make(map[string]domain.Todo)} }
 	random := rand.Intn(10000)
 	id := fmt.Sprintf("%d-%d", timestamp, random)
 	now := time.Now()
-	t := domain.Todo{ID: id, Title: title, Done: false, CreatedAt: now, UpdatedAt: now}
+	t := domain.Todo{ID: id, OwnerID: ownerID, Title: title, Done: false, CreatedAt: now, UpdatedAt: now}
 	m.data[id] = t
 	return t, nil
 }
 
-func (m *memRepo) List(ctx context.Context) ([]domain.Todo, error) {
+func (m *memRepo) List(ctx context.Context, ownerID string) ([]domain.Todo, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	out := make([]domain.Todo, 0, len(m.data))
 	for _, t := range m.data {
+		if t.OwnerID != ownerID {
+			continue
+		}
 		out = append(out, t)
 	}
 	// simple bubble sort by CreatedAt for determinism
@@ -49,11 +52,54 @@ func (m *memRepo) List(ctx context.Context) ([]domain.Todo, error) {
 	return out, nil
 }
 
-func (m *memRepo) Toggle(ctx context.Context, id string) (domain.Todo, error) {
+func (m *memRepo) ListPage(ctx context.Context, ownerID string, limit int, cursor string) ([]domain.Todo, string, error) {
+	if limit <= 0 {
+		return []domain.Todo{}, "", nil
+	}
+	all, err := m.List(ctx, ownerID)
+	if err != nil {
+		return nil, "", err
+	}
+	start := 0
+	if cursor != "" {
+		found := false
+		for i, t := range all {
+			if t.ID == cursor {
+				start = i + 1
+				found = true
+				break
+			}
+		}
+		// An unrecognized cursor (e.g. for a todo since deleted) ends the
+		// page here rather than restarting from the first page, matching
+		// the sql adapter's keyset join, which likewise finds no anchor row.
+		if !found {
+			return []domain.Todo{}, "", nil
+		}
+	}
+	if start >= len(all) {
+		return []domain.Todo{}, "", nil
+	}
+	end := start + limit
+	if end > len(all) {
+		end = len(all)
+	}
+	page := all[start:end]
+	next := ""
+	if end < len(all) {
+		next = page[len(page)-1].ID
+	}
+	return page, next, nil
+}
+
+// Ping always succeeds: there's no external dependency to check.
+func (m *memRepo) Ping(ctx context.Context) error { return nil }
+
+func (m *memRepo) Toggle(ctx context.Context, ownerID, id string) (domain.Todo, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	t, ok := m.data[id]
-	if !ok {
+	if !ok || t.OwnerID != ownerID {
 		return domain.Todo{}, errors.New("todo not found")
 	}
 	t.Done = !t.Done
@@ -62,12 +108,13 @@ func (m *memRepo) Toggle(ctx context.Context, id string) (domain.Todo, error) {
 	return t, nil
 }
 
-func (m *memRepo) Delete(ctx context.Context, id string) error {
+func (m *memRepo) Delete(ctx context.Context, ownerID, id string) (domain.Todo, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if _, ok := m.data[id]; !ok {
-		return errors.New("todo not found")
+	t, ok := m.data[id]
+	if !ok || t.OwnerID != ownerID {
+		return domain.Todo{}, errors.New("todo not found")
 	}
 	delete(m.data, id)
-	return nil
+	return t, nil
 }
@@ -0,0 +1,12 @@
+package memory
+
+import (
+	"testing"
+
+	"example.com/todo/internal/adapters/repo/repotest"
+	"example.com/todo/internal/ports"
+)
+
+func TestMemRepo(t *testing.T) {
+	repotest.Run(t, func() ports.TodoRepo { return New() })
+}
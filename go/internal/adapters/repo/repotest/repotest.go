@@ -0,0 +1,177 @@
+// Package repotest holds the ports.TodoRepo contract tests shared by every
+// adapter (memory, sql, ...), so they stay behaviorally identical.
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"example.com/todo/internal/ports"
+)
+
+// Run exercises a freshly created, empty repo against the full
+// ports.TodoRepo contract. Call it from each adapter's own _test.go with a
+// factory that returns a new repo per subtest.
+func Run(t *testing.T, newRepo func() ports.TodoRepo) {
+	t.Helper()
+
+	t.Run("CreateAndList", func(t *testing.T) { testCreateAndList(t, newRepo()) })
+	t.Run("ListIsScopedToOwner", func(t *testing.T) { testListIsScopedToOwner(t, newRepo()) })
+	t.Run("ToggleFlipsDone", func(t *testing.T) { testToggleFlipsDone(t, newRepo()) })
+	t.Run("ToggleByOtherOwnerNotFound", func(t *testing.T) { testToggleByOtherOwnerNotFound(t, newRepo()) })
+	t.Run("DeleteRemovesTodo", func(t *testing.T) { testDeleteRemovesTodo(t, newRepo()) })
+	t.Run("ListPagePaginates", func(t *testing.T) { testListPagePaginates(t, newRepo()) })
+	t.Run("ListPageUnknownCursorIsEmpty", func(t *testing.T) { testListPageUnknownCursorIsEmpty(t, newRepo()) })
+}
+
+func testCreateAndList(t *testing.T, repo ports.TodoRepo) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, "alice", "buy milk")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.Title != "buy milk" || created.OwnerID != "alice" || created.Done {
+		t.Fatalf("Create = %+v, want title %q owned by alice, not done", created, "buy milk")
+	}
+
+	list, err := repo.List(ctx, "alice")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != created.ID {
+		t.Fatalf("List = %+v, want [%+v]", list, created)
+	}
+}
+
+func testListIsScopedToOwner(t *testing.T, repo ports.TodoRepo) {
+	ctx := context.Background()
+	if _, err := repo.Create(ctx, "alice", "alice's todo"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := repo.Create(ctx, "bob", "bob's todo"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	aliceList, err := repo.List(ctx, "alice")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(aliceList) != 1 || aliceList[0].Title != "alice's todo" {
+		t.Fatalf("alice's List = %+v, want only her own todo", aliceList)
+	}
+}
+
+func testToggleFlipsDone(t *testing.T, repo ports.TodoRepo) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, "alice", "toggle me")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	toggled, err := repo.Toggle(ctx, "alice", created.ID)
+	if err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+	if !toggled.Done {
+		t.Fatalf("Toggle did not flip Done: %+v", toggled)
+	}
+
+	toggledAgain, err := repo.Toggle(ctx, "alice", created.ID)
+	if err != nil {
+		t.Fatalf("Toggle: %v", err)
+	}
+	if toggledAgain.Done {
+		t.Fatalf("second Toggle did not flip Done back: %+v", toggledAgain)
+	}
+}
+
+func testToggleByOtherOwnerNotFound(t *testing.T, repo ports.TodoRepo) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, "alice", "alice's todo")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := repo.Toggle(ctx, "bob", created.ID); err == nil {
+		t.Fatalf("Toggle by a different owner should fail, got nil error")
+	}
+}
+
+func testDeleteRemovesTodo(t *testing.T, repo ports.TodoRepo) {
+	ctx := context.Background()
+	created, err := repo.Create(ctx, "alice", "delete me")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	deleted, err := repo.Delete(ctx, "alice", created.ID)
+	if err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if deleted.ID != created.ID {
+		t.Fatalf("Delete = %+v, want the deleted todo %+v", deleted, created)
+	}
+
+	list, err := repo.List(ctx, "alice")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 0 {
+		t.Fatalf("List after Delete = %+v, want empty", list)
+	}
+
+	if _, err := repo.Delete(ctx, "alice", created.ID); err == nil {
+		t.Fatalf("second Delete should fail, got nil error")
+	}
+}
+
+func testListPagePaginates(t *testing.T, repo ports.TodoRepo) {
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if _, err := repo.Create(ctx, "alice", "todo"); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	page1, cursor1, err := repo.ListPage(ctx, "alice", 2, "")
+	if err != nil {
+		t.Fatalf("ListPage: %v", err)
+	}
+	if len(page1) != 2 || cursor1 == "" {
+		t.Fatalf("ListPage page 1 = %+v, cursor %q, want 2 items and a cursor", page1, cursor1)
+	}
+
+	page2, cursor2, err := repo.ListPage(ctx, "alice", 2, cursor1)
+	if err != nil {
+		t.Fatalf("ListPage: %v", err)
+	}
+	if len(page2) != 2 || cursor2 == "" {
+		t.Fatalf("ListPage page 2 = %+v, cursor %q, want 2 items and a cursor", page2, cursor2)
+	}
+
+	page3, cursor3, err := repo.ListPage(ctx, "alice", 2, cursor2)
+	if err != nil {
+		t.Fatalf("ListPage: %v", err)
+	}
+	if len(page3) != 1 || cursor3 != "" {
+		t.Fatalf("ListPage page 3 = %+v, cursor %q, want 1 item and no next cursor", page3, cursor3)
+	}
+}
+
+// testListPageUnknownCursorIsEmpty pins the behavior for a cursor that
+// doesn't match any of the caller's todos (e.g. one for a todo deleted since
+// the page was fetched): the page ends here rather than silently restarting
+// from the first page, which would re-deliver already-seen items.
+func testListPageUnknownCursorIsEmpty(t *testing.T, repo ports.TodoRepo) {
+	ctx := context.Background()
+	if _, err := repo.Create(ctx, "alice", "alice's todo"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	items, next, err := repo.ListPage(ctx, "alice", 2, "no-such-id")
+	if err != nil {
+		t.Fatalf("ListPage: %v", err)
+	}
+	if len(items) != 0 || next != "" {
+		t.Fatalf("ListPage with unknown cursor = %+v, cursor %q, want no items and no next cursor", items, next)
+	}
+}
@@ -0,0 +1,171 @@
+// Package sql implements ports.TodoRepo against database/sql, behind
+// embedded schema migrations. The DSN's scheme picks the driver: SQLite
+// (modernc.org/sqlite, pure Go) by default, Postgres (pgx) for
+// "postgres://"/"postgresql://" DSNs.
+package sql
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+
+	"example.com/todo/internal/domain"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens dsn, runs any pending migrations, and returns a ready Store.
+// Callers are responsible for calling Close when done.
+func Open(ctx context.Context, dsn string) (*Store, error) {
+	driver := "sqlite"
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		driver = "pgx"
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sql: open %s: %w", driver, err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("sql: ping: %w", err)
+	}
+	if err := migrate(ctx, db); err != nil {
+		return nil, fmt.Errorf("sql: migrate: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error { return s.db.Close() }
+
+// Ping implements ports.Pinger so /health can report a down database as 503.
+func (s *Store) Ping(ctx context.Context) error { return s.db.PingContext(ctx) }
+
+func (s *Store) Create(ctx context.Context, ownerID, title string) (domain.Todo, error) {
+	now := time.Now().UTC()
+	t := domain.Todo{ID: newID(), OwnerID: ownerID, Title: title, Done: false, CreatedAt: now, UpdatedAt: now}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO todos (id, owner_id, title, done, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		t.ID, t.OwnerID, t.Title, t.Done, t.CreatedAt, t.UpdatedAt)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+	return t, nil
+}
+
+func (s *Store) List(ctx context.Context, ownerID string) ([]domain.Todo, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, owner_id, title, done, created_at, updated_at FROM todos WHERE owner_id = $1 ORDER BY created_at, id`,
+		ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTodos(rows)
+}
+
+// ListPage returns one page ordered by (created_at, id) via keyset
+// pagination: cursor is the last id seen on the previous page, so this stays
+// an indexed range scan instead of an OFFSET that gets slower per page.
+func (s *Store) ListPage(ctx context.Context, ownerID string, limit int, cursor string) ([]domain.Todo, string, error) {
+	if limit <= 0 {
+		return []domain.Todo{}, "", nil
+	}
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if cursor == "" {
+		rows, err = s.db.QueryContext(ctx,
+			`SELECT id, owner_id, title, done, created_at, updated_at FROM todos
+			 WHERE owner_id = $1 ORDER BY created_at, id LIMIT $2`,
+			ownerID, limit+1)
+	} else {
+		rows, err = s.db.QueryContext(ctx,
+			`SELECT t.id, t.owner_id, t.title, t.done, t.created_at, t.updated_at
+			 FROM todos t JOIN todos c ON c.id = $2 AND c.owner_id = $1
+			 WHERE t.owner_id = $1 AND (t.created_at, t.id) > (c.created_at, c.id)
+			 ORDER BY t.created_at, t.id LIMIT $3`,
+			ownerID, cursor, limit+1)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	items, err := scanTodos(rows)
+	if err != nil {
+		return nil, "", err
+	}
+	next := ""
+	if len(items) > limit {
+		items = items[:limit]
+		next = items[len(items)-1].ID
+	}
+	return items, next, nil
+}
+
+func (s *Store) Toggle(ctx context.Context, ownerID, id string) (domain.Todo, error) {
+	now := time.Now().UTC()
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE todos SET done = NOT done, updated_at = $3 WHERE id = $1 AND owner_id = $2`,
+		id, ownerID, now)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.Todo{}, errors.New("todo not found")
+	}
+	return s.get(ctx, ownerID, id)
+}
+
+func (s *Store) Delete(ctx context.Context, ownerID, id string) (domain.Todo, error) {
+	t, err := s.get(ctx, ownerID, id)
+	if err != nil {
+		return domain.Todo{}, errors.New("todo not found")
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM todos WHERE id = $1 AND owner_id = $2`, id, ownerID)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.Todo{}, errors.New("todo not found")
+	}
+	return t, nil
+}
+
+func (s *Store) get(ctx context.Context, ownerID, id string) (domain.Todo, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT id, owner_id, title, done, created_at, updated_at FROM todos WHERE id = $1 AND owner_id = $2`,
+		id, ownerID)
+	var t domain.Todo
+	if err := row.Scan(&t.ID, &t.OwnerID, &t.Title, &t.Done, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return domain.Todo{}, err
+	}
+	return t, nil
+}
+
+func scanTodos(rows *sql.Rows) ([]domain.Todo, error) {
+	out := []domain.Todo{}
+	for rows.Next() {
+		var t domain.Todo
+		if err := rows.Scan(&t.ID, &t.OwnerID, &t.Title, &t.Done, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+func newID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return fmt.Sprintf("%x", b)
+}
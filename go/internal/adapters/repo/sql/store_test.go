@@ -0,0 +1,22 @@
+package sql
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"example.com/todo/internal/adapters/repo/repotest"
+	"example.com/todo/internal/ports"
+)
+
+func TestStore(t *testing.T) {
+	repotest.Run(t, func() ports.TodoRepo {
+		dsn := filepath.Join(t.TempDir(), "todo.db")
+		store, err := Open(context.Background(), dsn)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	})
+}
@@ -7,31 +7,66 @@ import (
 
 	"example.com/todo/internal/domain"
 	"example.com/todo/internal/ports"
+	"example.com/todo/internal/reqctx"
 )
 
 // TodoService holds business use-cases. It depends on ports, not implementations.
 type TodoService struct {
-	repo ports.TodoRepo
+	repo      ports.TodoRepo
+	logger    ports.Logger
+	publisher ports.EventPublisher
 }
 
-func NewTodoService(repo ports.TodoRepo) *TodoService { return &TodoService{repo: repo} }
+func NewTodoService(repo ports.TodoRepo, logger ports.Logger, publisher ports.EventPublisher) *TodoService {
+	return &TodoService{repo: repo, logger: logger, publisher: publisher}
+}
 
-func (s *TodoService) Create(ctx context.Context, title string) (domain.Todo, error) {
+func (s *TodoService) Create(ctx context.Context, userID, title string) (domain.Todo, error) {
 	title = strings.TrimSpace(title)
 	if title == "" {
 		return domain.Todo{}, errors.New("title is required")
 	}
-	return s.repo.Create(ctx, title)
+	t, err := s.repo.Create(ctx, userID, title)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+	s.logger.Info("todo created", "request_id", reqctx.RequestID(ctx), "user_id", userID, "todo_id", t.ID)
+	s.publisher.Publish(userID, ports.TodoCreated, t)
+	return t, nil
+}
+
+func (s *TodoService) List(ctx context.Context, userID string) ([]domain.Todo, error) {
+	return s.repo.List(ctx, userID)
 }
 
-func (s *TodoService) List(ctx context.Context) ([]domain.Todo, error) {
-	return s.repo.List(ctx)
+// defaultPageLimit caps ListPage when the caller doesn't specify one.
+const defaultPageLimit = 20
+
+// ListPage returns one page of userID's todos ordered by creation time,
+// starting after cursor. limit <= 0 falls back to defaultPageLimit.
+func (s *TodoService) ListPage(ctx context.Context, userID string, limit int, cursor string) ([]domain.Todo, string, error) {
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+	return s.repo.ListPage(ctx, userID, limit, cursor)
 }
 
-func (s *TodoService) Toggle(ctx context.Context, id string) (domain.Todo, error) {
-	return s.repo.Toggle(ctx, id)
+func (s *TodoService) Toggle(ctx context.Context, userID, id string) (domain.Todo, error) {
+	t, err := s.repo.Toggle(ctx, userID, id)
+	if err != nil {
+		return domain.Todo{}, err
+	}
+	s.logger.Info("todo toggled", "request_id", reqctx.RequestID(ctx), "user_id", userID, "todo_id", t.ID, "done", t.Done)
+	s.publisher.Publish(userID, ports.TodoToggled, t)
+	return t, nil
 }
 
-func (s *TodoService) Delete(ctx context.Context, id string) error {
-	return s.repo.Delete(ctx, id)
+func (s *TodoService) Delete(ctx context.Context, userID, id string) error {
+	t, err := s.repo.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	s.logger.Info("todo deleted", "request_id", reqctx.RequestID(ctx), "user_id", userID, "todo_id", id)
+	s.publisher.Publish(userID, ports.TodoDeleted, t)
+	return nil
 }
@@ -5,6 +5,7 @@ import "time"
 // Todo is an aggregate root in our domain.
 type Todo struct {
 	ID        string    `json:"id"`
+	OwnerID   string    `json:"-"`
 	Title     string    `json:"title"`
 	Done      bool      `json:"done"`
 	CreatedAt time.Time `json:"createdAt"`
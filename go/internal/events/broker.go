@@ -0,0 +1,138 @@
+// Package events is an in-process pub/sub hub for todo changes. TodoService
+// publishes through it; the HTTP adapter subscribes on behalf of SSE and
+// WebSocket clients. There's one topic per authenticated user.
+package events
+
+import (
+	"sync"
+
+	"example.com/todo/internal/domain"
+	"example.com/todo/internal/ports"
+)
+
+// Event is one change to a user's todo list, as delivered to subscribers.
+type Event struct {
+	ID     uint64
+	Type   ports.EventType
+	UserID string
+	Todo   domain.Todo
+}
+
+const (
+	// ringSize bounds how far back Last-Event-ID replay can reach.
+	ringSize = 256
+	// subscriberBuffer is how many events a slow subscriber can fall behind
+	// before it's dropped.
+	subscriberBuffer = 16
+)
+
+// topic is one user's event stream: a ring buffer for replay plus the set
+// of channels currently subscribed to it.
+type topic struct {
+	mu          sync.Mutex
+	nextID      uint64
+	ring        []Event
+	subscribers map[chan Event]struct{}
+}
+
+// Broker implements ports.EventPublisher and is shared between TodoService
+// (the publisher) and the HTTP adapter (the subscriber side, behind
+// /todos/stream and /todos/ws).
+type Broker struct {
+	logger ports.Logger
+
+	mu     sync.Mutex
+	topics map[string]*topic
+}
+
+func NewBroker(logger ports.Logger) *Broker {
+	return &Broker{logger: logger, topics: make(map[string]*topic)}
+}
+
+func (b *Broker) topicFor(userID string) *topic {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.topics[userID]
+	if !ok {
+		t = &topic{subscribers: make(map[chan Event]struct{})}
+		b.topics[userID] = t
+	}
+	return t
+}
+
+// Publish records evt in userID's topic and fans it out to every current
+// subscriber, under the topic lock. A subscriber whose channel is already
+// full is dropped (removed from subscribers and closed) rather than
+// blocking delivery to everyone else; closing it here, while still holding
+// the lock, is what lets the dropped subscriber's reader return instead of
+// hanging forever, without risking a second, concurrent Publish call
+// sending on that same channel after it's closed.
+func (b *Broker) Publish(userID string, typ ports.EventType, todo domain.Todo) {
+	t := b.topicFor(userID)
+
+	t.mu.Lock()
+	t.nextID++
+	evt := Event{ID: t.nextID, Type: typ, UserID: userID, Todo: todo}
+	t.ring = append(t.ring, evt)
+	if len(t.ring) > ringSize {
+		t.ring = t.ring[len(t.ring)-ringSize:]
+	}
+	var dropped int
+	for ch := range t.subscribers {
+		select {
+		case ch <- evt:
+		default:
+			delete(t.subscribers, ch)
+			close(ch)
+			dropped++
+		}
+	}
+	t.mu.Unlock()
+
+	for i := 0; i < dropped; i++ {
+		b.logger.Warn("dropping slow event subscriber", "user_id", userID)
+	}
+}
+
+// Subscribe registers a new subscriber for userID and returns its event
+// channel plus any buffered events after lastEventID, for Last-Event-ID
+// replay. The caller must invoke unsubscribe when its connection ends.
+func (b *Broker) Subscribe(userID string, lastEventID uint64) (ch chan Event, replay []Event, unsubscribe func()) {
+	t := b.topicFor(userID)
+
+	t.mu.Lock()
+	for _, evt := range t.ring {
+		if evt.ID > lastEventID {
+			replay = append(replay, evt)
+		}
+	}
+	ch = make(chan Event, subscriberBuffer)
+	t.subscribers[ch] = struct{}{}
+	t.mu.Unlock()
+
+	unsubscribe = func() {
+		t.mu.Lock()
+		if _, ok := t.subscribers[ch]; ok {
+			delete(t.subscribers, ch)
+			close(ch)
+		}
+		t.mu.Unlock()
+	}
+	return ch, replay, unsubscribe
+}
+
+// Close disconnects every subscriber on every topic so graceful shutdown
+// can unblock SSE/WebSocket handlers instead of waiting on a channel that
+// will never receive again.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, t := range b.topics {
+		t.mu.Lock()
+		for ch := range t.subscribers {
+			delete(t.subscribers, ch)
+			close(ch)
+		}
+		t.mu.Unlock()
+	}
+}
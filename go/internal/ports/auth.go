@@ -0,0 +1,19 @@
+package ports
+
+import "context"
+
+// AuthVerifier is a driven port: the HTTP adapter uses it to validate bearer
+// tokens presented by callers and recover the authenticated subject (user ID).
+// Swappable implementations include HMAC-signed JWTs (the default), OIDC, and
+// static API keys.
+type AuthVerifier interface {
+	Verify(ctx context.Context, token string) (subject string, err error)
+}
+
+// TokenIssuer is a driven port: it mints the access/refresh token pair for
+// /auth/login and mints a fresh pair from a valid refresh token for
+// /auth/refresh. Implementations decide token format, signing, and TTLs.
+type TokenIssuer interface {
+	Issue(ctx context.Context, subject string) (access, refresh string, err error)
+	Refresh(ctx context.Context, refreshToken string) (access, refresh string, err error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import "example.com/todo/internal/domain"
+
+// EventType identifies what happened to a todo.
+type EventType string
+
+const (
+	TodoCreated EventType = "todo.created"
+	TodoToggled EventType = "todo.toggled"
+	TodoDeleted EventType = "todo.deleted"
+)
+
+// EventPublisher is a driven port: TodoService reports domain changes
+// through it so anyone subscribed to a user's event stream (SSE,
+// WebSocket, ...) hears about them. Implemented by internal/events.Broker.
+type EventPublisher interface {
+	Publish(userID string, typ EventType, todo domain.Todo)
+}
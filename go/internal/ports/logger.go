@@ -0,0 +1,11 @@
+package ports
+
+// Logger is a driven port for structured, leveled logging with key-value
+// fields (alternating key, value, key, value, ...). Adapters decide the
+// backend (stdlib log/slog, zap, a no-op for tests).
+type Logger interface {
+	Debug(msg string, kv ...any)
+	Info(msg string, kv ...any)
+	Warn(msg string, kv ...any)
+	Error(msg string, kv ...any)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// Pinger is a driven port for components that can check their own
+// reachability (most notably a database-backed TodoRepo), so /health can
+// report 503 instead of a false "ok".
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
@@ -6,9 +6,17 @@ import (
 )
 
 // TodoRepo is a driven port: the app depends on this interface; adapters implement it.
+// Every todo is owned by the authenticated user that created it; lookups by
+// id are scoped to ownerID and must behave as "not found" for other users' todos.
 type TodoRepo interface {
-	Create(ctx context.Context, title string) (domain.Todo, error)
-	List(ctx context.Context) ([]domain.Todo, error)
-	Toggle(ctx context.Context, id string) (domain.Todo, error)
-	Delete(ctx context.Context, id string) error
+	Create(ctx context.Context, ownerID, title string) (domain.Todo, error)
+	List(ctx context.Context, ownerID string) ([]domain.Todo, error)
+	// ListPage returns up to limit todos ordered by creation time, starting
+	// after cursor (the empty string for the first page). nextCursor is ""
+	// once there are no more results.
+	ListPage(ctx context.Context, ownerID string, limit int, cursor string) (items []domain.Todo, nextCursor string, err error)
+	Toggle(ctx context.Context, ownerID, id string) (domain.Todo, error)
+	// Delete removes a todo and returns it as it was just before deletion, so
+	// callers (e.g. the event publisher) can report what was deleted.
+	Delete(ctx context.Context, ownerID, id string) (domain.Todo, error)
 }
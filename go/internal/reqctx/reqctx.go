@@ -0,0 +1,19 @@
+// Package reqctx carries the per-request correlation ID through
+// context.Context so the HTTP adapter and the application core can both
+// enrich their logs with it without the core depending on the HTTP layer.
+package reqctx
+
+import "context"
+
+type key struct{}
+
+// WithRequestID returns a copy of ctx carrying id as the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
+
+// RequestID returns the request ID stored on ctx, or "" if none was set.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(key{}).(string)
+	return id
+}
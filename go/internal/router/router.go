@@ -0,0 +1,132 @@
+// Package router layers per-path method dispatch and typed path parameters on
+// top of http.ServeMux, replacing hand-rolled strings.Split path parsing.
+package router
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type ctxKey int
+
+const paramsKey ctxKey = iota
+
+// Router dispatches requests to a method -> http.Handler map per registered
+// pattern, extracting {name} path segments and generating a correct Allow
+// header for unsupported methods.
+type Router struct {
+	mux    *http.ServeMux
+	routes []*route
+}
+
+type route struct {
+	segments []segment
+	methods  map[string]http.Handler
+	allow    string
+}
+
+// segment is either a literal path component or, when name is non-empty, a
+// {name} parameter that matches any single component.
+type segment struct {
+	name    string
+	literal string
+}
+
+// New returns an empty Router.
+func New() *Router {
+	rt := &Router{}
+	rt.mux = http.NewServeMux()
+	rt.mux.HandleFunc("/", rt.dispatch)
+	return rt
+}
+
+// Route registers pattern (e.g. "/todos/{id}/toggle") with a method ->
+// handler map. Register more specific literal patterns before parameterized
+// ones that could also match the same path, since the first matching route
+// wins. A request whose path matches pattern but whose method isn't present
+// in methods gets a 405 with an Allow header listing the registered methods.
+func (rt *Router) Route(pattern string, methods map[string]http.Handler) {
+	allowed := make([]string, 0, len(methods))
+	for m := range methods {
+		allowed = append(allowed, m)
+	}
+	sort.Strings(allowed)
+	rt.routes = append(rt.routes, &route{
+		segments: splitPattern(pattern),
+		methods:  methods,
+		allow:    strings.Join(allowed, ", "),
+	})
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) { rt.mux.ServeHTTP(w, r) }
+
+func (rt *Router) dispatch(w http.ResponseWriter, r *http.Request) {
+	reqSegs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	var matched *route
+	var params map[string]string
+	for _, rte := range rt.routes {
+		if p, ok := rte.match(reqSegs); ok {
+			matched, params = rte, p
+			break
+		}
+	}
+	if matched == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	h, ok := matched.methods[r.Method]
+	if !ok {
+		w.Header().Set("Allow", matched.allow)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if len(params) > 0 {
+		r = r.WithContext(context.WithValue(r.Context(), paramsKey, params))
+	}
+	h.ServeHTTP(w, r)
+}
+
+func (rte *route) match(reqSegs []string) (map[string]string, bool) {
+	if len(reqSegs) != len(rte.segments) {
+		return nil, false
+	}
+	var params map[string]string
+	for i, seg := range rte.segments {
+		if seg.name != "" {
+			if params == nil {
+				params = make(map[string]string, 1)
+			}
+			params[seg.name] = reqSegs[i]
+			continue
+		}
+		if seg.literal != reqSegs[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func splitPattern(pattern string) []segment {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+	segs := make([]segment, len(parts))
+	for i, p := range parts {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			segs[i] = segment{name: strings.TrimSuffix(strings.TrimPrefix(p, "{"), "}")}
+		} else {
+			segs[i] = segment{literal: p}
+		}
+	}
+	return segs
+}
+
+// Param returns the value of the named path parameter extracted while
+// matching the current request's route, e.g. Param(r, "id") for a route
+// registered as "/todos/{id}". It returns "" if name wasn't captured.
+func Param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey).(map[string]string)
+	return params[name]
+}